@@ -0,0 +1,119 @@
+// Copyright © 2016 Abcum Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conv
+
+import (
+	"sort"
+	"strconv"
+)
+
+// MultiError collects the errors encountered while coercing the elements of
+// an array or the values of an object.
+type MultiError []error
+
+func (m MultiError) Error() string {
+	msgs := make([]string, len(m))
+	for i, err := range m {
+		msgs[i] = err.Error()
+	}
+	return strconv.Itoa(len(m)) + " conversion errors: " + joinErrors(msgs)
+}
+
+func joinErrors(msgs []string) string {
+	out := ""
+	for i, msg := range msgs {
+		if i > 0 {
+			out += "; "
+		}
+		out += msg
+	}
+	return out
+}
+
+// ConvertToArrayOf coerces obj to a []interface{} and recursively converts
+// every element to elem (eg "number", "array<string>").
+func ConvertToArrayOf(obj interface{}, elem string, path []string) (val []interface{}, err error) {
+
+	now, ok := obj.([]interface{})
+	if !ok {
+		return nil, withPath(newError("an array", obj), path)
+	}
+
+	val = make([]interface{}, len(now))
+
+	var errs MultiError
+
+	for i, item := range now {
+		itemPath := append(append([]string{}, path...), strconv.Itoa(i))
+		v, ierr := ConvertToWithPath(elem, "", itemPath, item)
+		if ierr != nil {
+			errs = append(errs, ierr)
+			continue
+		}
+		val[i] = v
+	}
+
+	if len(errs) > 0 {
+		return val, errs
+	}
+
+	return val, nil
+
+}
+
+// ConvertToObjectOf coerces obj to a map[string]interface{} and recursively
+// converts every value to elem (eg "datetime"). key, when non-empty, is
+// also used to validate each map key.
+func ConvertToObjectOf(obj interface{}, key, elem string, path []string) (val map[string]interface{}, err error) {
+
+	now, ok := obj.(map[string]interface{})
+	if !ok {
+		return nil, withPath(newError("an object", obj), path)
+	}
+
+	val = make(map[string]interface{}, len(now))
+
+	keys := make([]string, 0, len(now))
+	for k := range now {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var errs MultiError
+
+	for _, k := range keys {
+		item := now[k]
+		entryPath := append(append([]string{}, path...), k)
+		if key != "" {
+			if _, kerr := ConvertToWithPath(key, "", entryPath, k); kerr != nil {
+				errs = append(errs, kerr)
+				continue
+			}
+		}
+		v, verr := ConvertToWithPath(elem, "", entryPath, item)
+		if verr != nil {
+			errs = append(errs, verr)
+			continue
+		}
+		val[k] = v
+	}
+
+	if len(errs) > 0 {
+		return val, errs
+	}
+
+	return val, nil
+
+}
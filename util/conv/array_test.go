@@ -0,0 +1,118 @@
+// Copyright © 2016 Abcum Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conv
+
+import "testing"
+
+func TestParseTypeSpec(t *testing.T) {
+
+	spec, ok := ParseTypeSpec("array<number>")
+	if !ok || spec.Base != "array" || spec.Elem != "number" {
+		t.Fatalf("got %+v, %v", spec, ok)
+	}
+
+	spec, ok = ParseTypeSpec("object<string,datetime>")
+	if !ok || spec.Base != "object" || spec.Key != "string" || spec.Elem != "datetime" {
+		t.Fatalf("got %+v, %v", spec, ok)
+	}
+
+	if _, ok = ParseTypeSpec("string"); ok {
+		t.Fatal("expected a plain type name to report ok=false")
+	}
+
+	if _, ok = ParseTypeSpec("object<onlyone>"); ok {
+		t.Fatal("expected a malformed object spec to report ok=false")
+	}
+
+	spec, ok = ParseTypeSpec("object<object<string,string>,number>")
+	if !ok || spec.Key != "object<string,string>" || spec.Elem != "number" {
+		t.Fatalf("expected the top-level comma to be found outside the nested key, got %+v, %v", spec, ok)
+	}
+
+}
+
+func TestConvertToArrayOf(t *testing.T) {
+
+	val, err := ConvertToArrayOf([]interface{}{"1", "2", "3"}, "number", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(val) != 3 || val[0].(float64) != 1 {
+		t.Fatalf("got %+v", val)
+	}
+
+}
+
+func TestConvertToArrayOfCollectsPerIndexErrors(t *testing.T) {
+
+	_, err := ConvertToArrayOf([]interface{}{"1", "nope", "3"}, "number", []string{"tags"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	merr, ok := err.(MultiError)
+	if !ok || len(merr) != 1 {
+		t.Fatalf("expected a single-entry MultiError, got %#v", err)
+	}
+
+	ce := merr[0].(*ConvertError)
+	if ce.Pointer() != "/tags/1" {
+		t.Fatalf("got pointer %q", ce.Pointer())
+	}
+
+}
+
+func TestConvertToObjectOf(t *testing.T) {
+
+	val, err := ConvertToObjectOf(map[string]interface{}{"a": "1"}, "", "number", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if val["a"].(float64) != 1 {
+		t.Fatalf("got %+v", val)
+	}
+
+}
+
+func TestConvertToWithPathNestedArrayOfObject(t *testing.T) {
+
+	// array<object<string,number>> — a nested spec exercising path
+	// attribution two levels deep.
+	input := []interface{}{
+		map[string]interface{}{"score": "1"},
+		map[string]interface{}{"score": "not-a-number"},
+	}
+
+	_, err := ConvertToWithPath("array<object<string,number>>", "", []string{"items"}, input)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	merr, ok := err.(MultiError)
+	if !ok || len(merr) != 1 {
+		t.Fatalf("expected a single-entry MultiError, got %#v", err)
+	}
+
+	inner, ok := merr[0].(MultiError)
+	if !ok || len(inner) != 1 {
+		t.Fatalf("expected a nested MultiError, got %#v", merr[0])
+	}
+
+	ce := inner[0].(*ConvertError)
+	if ce.Pointer() != "/items/1/score" {
+		t.Fatalf("got pointer %q", ce.Pointer())
+	}
+
+}
@@ -17,7 +17,6 @@ package conv
 import (
 	"fmt"
 	"strconv"
-	"time"
 
 	"github.com/abcum/surreal/sql"
 
@@ -28,7 +27,7 @@ func toNumber(str string) (float64, error) {
 	val, err := strconv.ParseFloat(str, 64)
 	if err != nil {
 		val = 0.0
-		err = fmt.Errorf("Expected a number, but found '%v'", str)
+		err = newError("a number", str)
 
 	}
 	return float64(int64(val)), err
@@ -38,7 +37,7 @@ func toDouble(str string) (float64, error) {
 	val, err := strconv.ParseFloat(str, 64)
 	if err != nil {
 		val = 0.0
-		err = fmt.Errorf("Expected a number, but found '%v'", str)
+		err = newError("a number", str)
 	}
 	return float64(val), err
 }
@@ -47,58 +46,112 @@ func toBoolean(str string) (bool, error) {
 	val, err := strconv.ParseBool(str)
 	if err != nil {
 		val = false
-		err = fmt.Errorf("Expected a boolean, but found '%v'", str)
+		err = newError("a boolean", str)
 	}
 	return bool(val), err
 }
 
 // --------------------------------------------------
 
+// ConvertTo coerces obj to the schema type t, as used by `DEFINE FIELD ...
+// TYPE t`. k is the related table name, used by the "record" type. It is a
+// shorthand for ConvertToWithPath with a nil path.
 func ConvertTo(t, k string, obj interface{}) (val interface{}, err error) {
+	return ConvertToWithPath(t, k, nil, obj)
+}
+
+// ConvertToWithPath behaves like ConvertTo, but attaches path to any
+// *ConvertError it returns, so that errors encountered while coercing a
+// nested object or array can report a JSON-pointer style location such as
+// `/user/address/lat`.
+func ConvertToWithPath(t, k string, path []string, obj interface{}) (val interface{}, err error) {
+
+	if fn, ok := lookup(t); ok {
+		val, err = fn(obj, k)
+		return val, withPath(err, path)
+	}
+
+	if spec, ok := ParseTypeSpec(t); ok {
+		switch spec.Base {
+		case "array":
+			return ConvertToArrayOf(obj, spec.Elem, path)
+		case "object":
+			return ConvertToObjectOf(obj, spec.Key, spec.Elem, path)
+		case "point", "geometry":
+			if spec.Elem != "strict" {
+				return nil, withPath(newError(fmt.Sprintf("'%s<strict>'", spec.Base), t), path)
+			}
+			val, err = ConvertToPointWithConfig(obj, GeoConfig{Strict: true})
+			return val, withPath(err, path)
+		case "datetime":
+			conf, ok := lookupDatetimeLayouts(spec.Elem)
+			if !ok {
+				return nil, withPath(newError(fmt.Sprintf("a datetime layout set registered under %q", spec.Elem), t), path)
+			}
+			val, err = ConvertToDatetimeWithConfig(obj, conf)
+			return val, withPath(err, path)
+		}
+	}
+
 	switch t {
 	default:
 		return obj, nil
 	case "url":
-		return ConvertToUrl(obj)
+		val, err = ConvertToUrl(obj)
 	case "uuid":
-		return ConvertToUuid(obj)
+		val, err = ConvertToUuid(obj)
 	case "color":
-		return ConvertToColor(obj)
+		val, err = ConvertToColor(obj)
 	case "email":
-		return ConvertToEmail(obj)
+		val, err = ConvertToEmail(obj)
 	case "phone":
-		return ConvertToPhone(obj)
+		val, err = ConvertToPhone(obj)
 	case "array":
-		return ConvertToArray(obj)
+		val, err = ConvertToArray(obj)
 	case "object":
-		return ConvertToObject(obj)
+		val, err = ConvertToObject(obj)
 	case "domain":
-		return ConvertToDomain(obj)
+		val, err = ConvertToDomain(obj)
 	case "base64":
-		return ConvertToBase64(obj)
+		val, err = ConvertToBase64(obj)
 	case "string":
-		return ConvertToString(obj)
+		val, err = ConvertToString(obj)
 	case "number":
-		return ConvertToNumber(obj)
+		val, err = ConvertToNumber(obj)
 	case "double":
-		return ConvertToDouble(obj)
+		val, err = ConvertToDouble(obj)
 	case "boolean":
-		return ConvertToBoolean(obj)
+		val, err = ConvertToBoolean(obj)
 	case "datetime":
-		return ConvertToDatetime(obj)
+		val, err = ConvertToDatetime(obj)
 	case "latitude":
-		return ConvertToLatitude(obj)
+		val, err = ConvertToLatitude(obj)
 	case "longitude":
-		return ConvertToLongitude(obj)
+		val, err = ConvertToLongitude(obj)
+	case "ip":
+		val, err = ConvertToIP(obj)
+	case "ipv4":
+		val, err = ConvertToIPv4(obj)
+	case "ipv6":
+		val, err = ConvertToIPv6(obj)
+	case "cidr":
+		val, err = ConvertToCIDR(obj)
+	case "mac":
+		val, err = ConvertToMAC(obj)
+	case "point", "geometry":
+		val, err = ConvertToPoint(obj)
 	case "record":
-		return ConvertToRecord(obj, k)
+		val, err = ConvertToRecord(obj, k)
 	}
+
+	return val, withPath(err, path)
+
 }
 
 func ConvertToUrl(obj interface{}) (val string, err error) {
 	val = fmt.Sprintf("%v", obj)
 	if !govalidator.IsURL(val) {
-		err = fmt.Errorf("Expected a URL, but found '%v'", obj)
+		err = newError("a URL", obj)
 	}
 	return
 }
@@ -106,7 +159,7 @@ func ConvertToUrl(obj interface{}) (val string, err error) {
 func ConvertToUuid(obj interface{}) (val string, err error) {
 	val = fmt.Sprintf("%v", obj)
 	if !govalidator.IsUUID(val) {
-		err = fmt.Errorf("Expected a UUID, but found '%v'", obj)
+		err = newError("a UUID", obj)
 	}
 	return
 }
@@ -114,7 +167,7 @@ func ConvertToUuid(obj interface{}) (val string, err error) {
 func ConvertToEmail(obj interface{}) (val string, err error) {
 	val = fmt.Sprintf("%v", obj)
 	if !govalidator.IsEmail(val) {
-		err = fmt.Errorf("Expected an email address, but found '%v'", obj)
+		err = newError("an email address", obj)
 	}
 	return govalidator.NormalizeEmail(val)
 }
@@ -122,7 +175,7 @@ func ConvertToEmail(obj interface{}) (val string, err error) {
 func ConvertToPhone(obj interface{}) (val string, err error) {
 	val = fmt.Sprintf("%v", obj)
 	if !govalidator.Matches(val, `^[\s\d\+\-\(\)]+$`) {
-		err = fmt.Errorf("Expected a phone number, but found '%v'", obj)
+		err = newError("a phone number", obj)
 	}
 	return
 }
@@ -130,7 +183,7 @@ func ConvertToPhone(obj interface{}) (val string, err error) {
 func ConvertToColor(obj interface{}) (val string, err error) {
 	val = fmt.Sprintf("%v", obj)
 	if !govalidator.IsHexcolor(val) && !govalidator.IsRGBcolor(val) {
-		err = fmt.Errorf("Expected a HEX or RGB color, but found '%v'", obj)
+		err = newError("a HEX or RGB color", obj)
 	}
 	return
 }
@@ -139,7 +192,7 @@ func ConvertToArray(obj interface{}) (val []interface{}, err error) {
 	if now, ok := obj.([]interface{}); ok {
 		val = now
 	} else {
-		err = fmt.Errorf("Expected an array, but found '%v'", obj)
+		err = newError("an array", obj)
 	}
 	return
 }
@@ -148,7 +201,7 @@ func ConvertToObject(obj interface{}) (val map[string]interface{}, err error) {
 	if now, ok := obj.(map[string]interface{}); ok {
 		val = now
 	} else {
-		err = fmt.Errorf("Expected an object, but found '%v'", obj)
+		err = newError("an object", obj)
 	}
 	return
 }
@@ -156,7 +209,7 @@ func ConvertToObject(obj interface{}) (val map[string]interface{}, err error) {
 func ConvertToDomain(obj interface{}) (val string, err error) {
 	val = fmt.Sprintf("%v", obj)
 	if !govalidator.IsDNSName(val) {
-		err = fmt.Errorf("Expected a domain name, but found '%v'", obj)
+		err = newError("a domain name", obj)
 	}
 	return
 }
@@ -164,7 +217,7 @@ func ConvertToDomain(obj interface{}) (val string, err error) {
 func ConvertToBase64(obj interface{}) (val string, err error) {
 	val = fmt.Sprintf("%v", obj)
 	if !govalidator.IsBase64(val) {
-		err = fmt.Errorf("Expected base64 data, but found '%v'", obj)
+		err = newError("base64 data", obj)
 	}
 	return
 }
@@ -174,7 +227,7 @@ func ConvertToString(obj interface{}) (val string, err error) {
 	case string:
 		return now, err
 	case []interface{}, map[string]interface{}:
-		return val, fmt.Errorf("Expected a string, but found '%v'", obj)
+		return val, newError("a string", obj)
 	default:
 		return fmt.Sprintf("%v", obj), err
 	}
@@ -219,19 +272,10 @@ func ConvertToBoolean(obj interface{}) (val bool, err error) {
 	}
 }
 
-func ConvertToDatetime(obj interface{}) (val time.Time, err error) {
-	if now, ok := obj.(time.Time); ok {
-		val = now
-	} else {
-		err = fmt.Errorf("Expected a datetime, but found '%v'", obj)
-	}
-	return
-}
-
 func ConvertToLatitude(obj interface{}) (val float64, err error) {
 	str := fmt.Sprintf("%v", obj)
 	if !govalidator.IsLatitude(str) {
-		err = fmt.Errorf("Expected a latitude value, but found '%v'", obj)
+		err = newError("a latitude value", obj)
 	}
 	return govalidator.ToFloat(str)
 }
@@ -239,7 +283,7 @@ func ConvertToLatitude(obj interface{}) (val float64, err error) {
 func ConvertToLongitude(obj interface{}) (val float64, err error) {
 	str := fmt.Sprintf("%v", obj)
 	if !govalidator.IsLongitude(str) {
-		err = fmt.Errorf("Expected a longitude value, but found '%v'", obj)
+		err = newError("a longitude value", obj)
 	}
 	return govalidator.ToFloat(str)
 }
@@ -252,10 +296,10 @@ func ConvertToRecord(obj interface{}, tb string) (val *sql.Thing, err error) {
 		case "":
 			val = now
 		default:
-			err = fmt.Errorf("Expected a record of type '%s', but found '%v'", tb, obj)
+			err = newError(fmt.Sprintf("a record of type '%s'", tb), obj)
 		}
 	} else {
-		err = fmt.Errorf("Expected a record of type '%s', but found '%v'", tb, obj)
+		err = newError(fmt.Sprintf("a record of type '%s'", tb), obj)
 	}
 	return
 }
@@ -0,0 +1,183 @@
+// Copyright © 2016 Abcum Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conv
+
+import (
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ISO 8601 week dates (eg "2023-W05-3") aren't a time.Parse reference-time
+// token, so those are tried separately via parseISOWeek.
+var DefaultDatetimeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	time.RFC1123,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"2006-002",
+}
+
+var isoWeekPattern = regexp.MustCompile(`^(\d{4})-W(\d{2})-(\d)$`)
+
+// parseISOWeek parses eg "2023-W05-3". ok is false when str isn't a week
+// date, or names a week that doesn't exist in that year.
+func parseISOWeek(str string, loc *time.Location) (t time.Time, ok bool) {
+
+	m := isoWeekPattern.FindStringSubmatch(str)
+	if m == nil {
+		return t, false
+	}
+
+	year, _ := strconv.Atoi(m[1])
+	week, _ := strconv.Atoi(m[2])
+	weekday, _ := strconv.Atoi(m[3])
+	if week < 1 || week > 53 || weekday < 1 || weekday > 7 {
+		return t, false
+	}
+
+	// Jan 4th always falls in week 1 of the ISO year; find that week's
+	// Monday and step forward to the requested week and weekday.
+	jan4 := time.Date(year, 1, 4, 0, 0, 0, 0, loc)
+	isoWd := int(jan4.Weekday())
+	if isoWd == 0 {
+		isoWd = 7
+	}
+	monday := jan4.AddDate(0, 0, -(isoWd - 1))
+	t = monday.AddDate(0, 0, (week-1)*7+(weekday-1))
+
+	gotYear, gotWeek := t.ISOWeek()
+	if gotYear != year || gotWeek != week {
+		return t, false
+	}
+
+	return t, true
+
+}
+
+type DatetimeConfig struct {
+	// Layouts is the ordered list of time.Parse layouts tried against
+	// string input. The first layout that succeeds wins. Defaults to
+	// DefaultDatetimeLayouts when empty.
+	Layouts []string
+	// Location is used to interpret ambiguous-zone string input when
+	// TrustLocation is set. Defaults to time.UTC.
+	Location *time.Location
+	// TrustLocation interprets ambiguous-zone string input using Location
+	// rather than assuming UTC. The zero value assumes UTC, matching a
+	// literal DatetimeConfig{Layouts: ...} with no Location set.
+	TrustLocation bool
+}
+
+var DefaultDatetimeConfig = DatetimeConfig{
+	Location: time.UTC,
+}
+
+var (
+	datetimeLayoutsMu sync.RWMutex
+	datetimeLayouts   = make(map[string]DatetimeConfig)
+)
+
+// RegisterDatetimeLayouts makes conf selectable as `TYPE datetime<name>` in a
+// `DEFINE FIELD` statement, so a schema can ingest datetimes in a layout set
+// that differs from DefaultDatetimeLayouts (eg to parse a CSV export's own
+// date format, or to disable the ISO week fallback).
+func RegisterDatetimeLayouts(name string, conf DatetimeConfig) {
+	datetimeLayoutsMu.Lock()
+	defer datetimeLayoutsMu.Unlock()
+	datetimeLayouts[name] = conf
+}
+
+func UnregisterDatetimeLayouts(name string) {
+	datetimeLayoutsMu.Lock()
+	defer datetimeLayoutsMu.Unlock()
+	delete(datetimeLayouts, name)
+}
+
+func lookupDatetimeLayouts(name string) (conf DatetimeConfig, ok bool) {
+	datetimeLayoutsMu.RLock()
+	defer datetimeLayoutsMu.RUnlock()
+	conf, ok = datetimeLayouts[name]
+	return
+}
+
+func ConvertToDatetime(obj interface{}) (val time.Time, err error) {
+	return ConvertToDatetimeWithConfig(obj, DefaultDatetimeConfig)
+}
+
+func ConvertToDatetimeWithConfig(obj interface{}, conf DatetimeConfig) (val time.Time, err error) {
+
+	loc := time.UTC
+	if conf.TrustLocation && conf.Location != nil {
+		loc = conf.Location
+	}
+
+	switch now := obj.(type) {
+
+	case time.Time:
+		return now.UTC(), nil
+
+	case int64:
+		return fromUnix(now).UTC(), nil
+
+	case float64:
+		return fromUnix(int64(now)).UTC(), nil
+
+	case string:
+		layouts := conf.Layouts
+		usingDefaults := len(layouts) == 0
+		if usingDefaults {
+			layouts = DefaultDatetimeLayouts
+		}
+		for _, layout := range layouts {
+			if t, perr := time.ParseInLocation(layout, now, loc); perr == nil {
+				return t.UTC(), nil
+			}
+		}
+
+		// ISO week dates aren't a layout string, so they're only tried
+		// when Layouts wasn't overridden to restrict accepted forms.
+		if usingDefaults {
+			if t, ok := parseISOWeek(now, loc); ok {
+				return t.UTC(), nil
+			}
+		}
+
+	}
+
+	return val, newError("a datetime", obj)
+
+}
+
+// fromUnix auto-detects whether ts is in seconds, milliseconds,
+// microseconds, or nanoseconds from its magnitude.
+func fromUnix(ts int64) time.Time {
+	abs := ts
+	if abs < 0 {
+		abs = -abs
+	}
+	switch {
+	case abs >= 1e18:
+		return time.Unix(0, ts)
+	case abs >= 1e15:
+		return time.Unix(ts/1e6, (ts%1e6)*1e3)
+	case abs >= 1e12:
+		return time.Unix(ts/1e3, (ts%1e3)*1e6)
+	default:
+		return time.Unix(ts, 0)
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright © 2016 Abcum Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conv
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConvertToDatetimeFromTime(t *testing.T) {
+
+	in := time.Date(2023, 2, 1, 12, 0, 0, 0, time.FixedZone("X", 3600))
+	got, err := ConvertToDatetime(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.Equal(in) || got.Location() != time.UTC {
+		t.Fatalf("got %v", got)
+	}
+
+}
+
+func TestConvertToDatetimeFromUnixAutoDetectsUnit(t *testing.T) {
+
+	want := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []int64{
+		want.Unix(),
+		want.UnixNano() / int64(time.Millisecond),
+		want.UnixNano(),
+	}
+
+	for _, ts := range cases {
+		got, err := ConvertToDatetime(ts)
+		if err != nil {
+			t.Fatalf("%d: unexpected error: %v", ts, err)
+		}
+		if !got.Equal(want) {
+			t.Fatalf("%d: got %v, want %v", ts, got, want)
+		}
+	}
+
+}
+
+func TestConvertToDatetimeFromString(t *testing.T) {
+
+	cases := []string{
+		"2023-02-01T00:00:00Z",
+		"2023-02-01 00:00:00",
+		"2023-02-01",
+		"2023-032",
+	}
+
+	want := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, s := range cases {
+		got, err := ConvertToDatetime(s)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", s, err)
+		}
+		if !got.Equal(want) {
+			t.Fatalf("%q: got %v, want %v", s, got, want)
+		}
+	}
+
+}
+
+func TestConvertToDatetimeFromISOWeek(t *testing.T) {
+
+	got, err := ConvertToDatetime("2023-W05-3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+}
+
+func TestConvertToDatetimeRejectsInvalidISOWeek(t *testing.T) {
+
+	if _, err := ConvertToDatetime("2023-W54-1"); err == nil {
+		t.Fatal("expected a nonexistent ISO week to be rejected")
+	}
+
+}
+
+func TestConvertToDatetimeRejectsGarbage(t *testing.T) {
+
+	if _, err := ConvertToDatetime("not a date"); err == nil {
+		t.Fatal("expected an error")
+	}
+
+}
+
+func TestConvertToDatetimeWithConfigCustomLayoutsExcludeISOWeek(t *testing.T) {
+
+	conf := DatetimeConfig{Layouts: []string{"2006-01-02"}}
+
+	if _, err := ConvertToDatetimeWithConfig("2023-W05-3", conf); err == nil {
+		t.Fatal("expected custom Layouts to exclude ISO week dates")
+	}
+
+	if _, err := ConvertToDatetimeWithConfig("2023-02-01", conf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+}
+
+func TestConvertToWithPathDatetimeNamedLayouts(t *testing.T) {
+
+	RegisterDatetimeLayouts("us-date", DatetimeConfig{Layouts: []string{"01/02/2006"}})
+	defer UnregisterDatetimeLayouts("us-date")
+
+	val, err := ConvertTo("datetime<us-date>", "", "02/01/2023")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC); !val.(time.Time).Equal(want) {
+		t.Fatalf("got %v", val)
+	}
+
+	if _, err := ConvertTo("datetime<unregistered>", "", "2023-02-01"); err == nil {
+		t.Fatal("expected an unregistered layout name to be rejected")
+	}
+
+}
@@ -0,0 +1,64 @@
+// Copyright © 2016 Abcum Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conv
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConvertError is returned whenever a value can not be converted to the
+// requested type, with Path giving a JSON-pointer style location such as
+// `/user/address/lat` when the failure is inside a nested object or array.
+type ConvertError struct {
+	Expected string
+	Got      string
+	Value    interface{}
+	Path     []string
+}
+
+func newError(expected string, value interface{}) *ConvertError {
+	return &ConvertError{
+		Expected: expected,
+		Got:      fmt.Sprintf("%T", value),
+		Value:    value,
+	}
+}
+
+func (e *ConvertError) Error() string {
+	msg := fmt.Sprintf("Expected %s, but found '%v'", e.Expected, e.Value)
+	if len(e.Path) > 0 {
+		msg = fmt.Sprintf("%s at '%s'", msg, e.Pointer())
+	}
+	return msg
+}
+
+// Pointer returns Path in JSON-pointer notation, eg `/user/address/lat`.
+func (e *ConvertError) Pointer() string {
+	if len(e.Path) == 0 {
+		return ""
+	}
+	return "/" + strings.Join(e.Path, "/")
+}
+
+func withPath(err error, path []string) error {
+	if err == nil || len(path) == 0 {
+		return err
+	}
+	if ce, ok := err.(*ConvertError); ok && len(ce.Path) == 0 {
+		ce.Path = path
+	}
+	return err
+}
@@ -0,0 +1,79 @@
+// Copyright © 2016 Abcum Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conv
+
+import "testing"
+
+func TestConvertErrorBackwardCompatMessage(t *testing.T) {
+
+	_, err := ConvertToUrl("not a url")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	want := "Expected a URL, but found 'not a url'"
+	if err.Error() != want {
+		t.Fatalf("got %q, want %q", err.Error(), want)
+	}
+
+}
+
+func TestConvertErrorPointer(t *testing.T) {
+
+	e := &ConvertError{Expected: "a number", Value: "x", Path: []string{"user", "address", "lat"}}
+
+	if got, want := e.Pointer(), "/user/address/lat"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	if got, want := e.Error(), "Expected a number, but found 'x' at '/user/address/lat'"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	if (&ConvertError{Expected: "x"}).Pointer() != "" {
+		t.Fatal("expected empty pointer for empty path")
+	}
+
+}
+
+func TestConvertToWithPathAttachesPath(t *testing.T) {
+
+	_, err := ConvertToWithPath("number", "", []string{"user", "age"}, "not a number")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	ce, ok := err.(*ConvertError)
+	if !ok {
+		t.Fatalf("expected *ConvertError, got %T", err)
+	}
+
+	if ce.Pointer() != "/user/age" {
+		t.Fatalf("got %q", ce.Pointer())
+	}
+
+}
+
+func TestWithPathDoesNotOverwriteExistingPath(t *testing.T) {
+
+	ce := &ConvertError{Expected: "a number", Path: []string{"inner"}}
+
+	err := withPath(ce, []string{"outer"})
+
+	if got := err.(*ConvertError).Pointer(); got != "/inner" {
+		t.Fatalf("got %q, want /inner", got)
+	}
+
+}
@@ -0,0 +1,115 @@
+// Copyright © 2016 Abcum Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conv
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/abcum/surreal/sql"
+
+	"github.com/asaskevich/govalidator"
+)
+
+type GeoConfig struct {
+	// Strict rejects array and "lat,lon" string input whose coordinates
+	// both fall inside the overlapping -90..90 range, since that input is
+	// ambiguous as to axis order. Only the explicitly-ordered GeoJSON
+	// object form is trusted in that case.
+	Strict bool
+}
+
+var DefaultGeoConfig = GeoConfig{Strict: false}
+
+// ConvertToPoint accepts a `[lon, lat]` array, a GeoJSON
+// `{type:"Point", coordinates:[lon,lat]}` object, or a `"lat,lon"` string.
+func ConvertToPoint(obj interface{}) (val *sql.Point, err error) {
+	return ConvertToPointWithConfig(obj, DefaultGeoConfig)
+}
+
+func ConvertToPointWithConfig(obj interface{}, conf GeoConfig) (val *sql.Point, err error) {
+
+	lon, lat, explicit, err := extractLonLat(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if !govalidator.IsLatitude(fmt.Sprintf("%v", lat)) {
+		return nil, newError("a latitude value", lat)
+	}
+
+	if !govalidator.IsLongitude(fmt.Sprintf("%v", lon)) {
+		return nil, newError("a longitude value", lon)
+	}
+
+	if conf.Strict && !explicit && lon >= -90 && lon <= 90 && lat >= -90 && lat <= 90 {
+		return nil, newError("an unambiguous point (use GeoJSON in strict mode)", obj)
+	}
+
+	return &sql.Point{Longitude: lon, Latitude: lat}, nil
+
+}
+
+// extractLonLat returns whether obj unambiguously ordered its coordinates
+// (only true for the GeoJSON object form).
+func extractLonLat(obj interface{}) (lon, lat float64, explicit bool, err error) {
+
+	switch now := obj.(type) {
+
+	case []interface{}:
+		if len(now) != 2 {
+			return 0, 0, false, newError("a [lon, lat] point", obj)
+		}
+		if lon, err = toDouble(fmt.Sprintf("%v", now[0])); err != nil {
+			return 0, 0, false, newError("a [lon, lat] point", obj)
+		}
+		if lat, err = toDouble(fmt.Sprintf("%v", now[1])); err != nil {
+			return 0, 0, false, newError("a [lon, lat] point", obj)
+		}
+		return lon, lat, false, nil
+
+	case map[string]interface{}:
+		typ, _ := now["type"].(string)
+		coords, ok := now["coordinates"].([]interface{})
+		if typ != "Point" || !ok || len(coords) != 2 {
+			return 0, 0, false, newError("a GeoJSON Point", obj)
+		}
+		if lon, err = toDouble(fmt.Sprintf("%v", coords[0])); err != nil {
+			return 0, 0, false, newError("a GeoJSON Point", obj)
+		}
+		if lat, err = toDouble(fmt.Sprintf("%v", coords[1])); err != nil {
+			return 0, 0, false, newError("a GeoJSON Point", obj)
+		}
+		return lon, lat, true, nil
+
+	case string:
+		parts := strings.SplitN(now, ",", 2)
+		if len(parts) != 2 {
+			return 0, 0, false, newError(`a "lat,lon" point`, obj)
+		}
+		if lat, err = toDouble(strings.TrimSpace(parts[0])); err != nil {
+			return 0, 0, false, newError(`a "lat,lon" point`, obj)
+		}
+		if lon, err = toDouble(strings.TrimSpace(parts[1])); err != nil {
+			return 0, 0, false, newError(`a "lat,lon" point`, obj)
+		}
+		return lon, lat, false, nil
+
+	default:
+		return 0, 0, false, newError("a point", obj)
+
+	}
+
+}
@@ -0,0 +1,131 @@
+// Copyright © 2016 Abcum Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conv
+
+import (
+	"testing"
+
+	"github.com/abcum/surreal/sql"
+)
+
+func TestConvertToPointFromArray(t *testing.T) {
+
+	p, err := ConvertToPoint([]interface{}{"-0.1", "51.5"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Longitude != -0.1 || p.Latitude != 51.5 {
+		t.Fatalf("got %+v", p)
+	}
+
+}
+
+func TestConvertToPointFromGeoJSON(t *testing.T) {
+
+	geojson := map[string]interface{}{
+		"type":        "Point",
+		"coordinates": []interface{}{"-0.1", "51.5"},
+	}
+
+	p, err := ConvertToPoint(geojson)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Longitude != -0.1 || p.Latitude != 51.5 {
+		t.Fatalf("got %+v", p)
+	}
+
+}
+
+func TestConvertToPointFromString(t *testing.T) {
+
+	p, err := ConvertToPoint("51.5,-0.1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Longitude != -0.1 || p.Latitude != 51.5 {
+		t.Fatalf("got %+v", p)
+	}
+
+}
+
+func TestConvertToPointStrictRejectsAmbiguousAxisOrder(t *testing.T) {
+
+	// Both components fall inside -90..90, so a plain array is ambiguous
+	// as to which is lon and which is lat.
+	_, err := ConvertToPointWithConfig([]interface{}{"40", "-10"}, GeoConfig{Strict: true})
+	if err == nil {
+		t.Fatal("expected strict mode to reject an ambiguous array point")
+	}
+
+	// Unambiguous because |lon| > 90, so it can't be mistaken for a latitude.
+	p, err := ConvertToPointWithConfig([]interface{}{"120", "-10"}, GeoConfig{Strict: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Longitude != 120 || p.Latitude != -10 {
+		t.Fatalf("got %+v", p)
+	}
+
+	// GeoJSON's coordinate order is unambiguous by spec, so strict mode
+	// trusts it even when both components fall inside -90..90.
+	geojson := map[string]interface{}{
+		"type":        "Point",
+		"coordinates": []interface{}{"40", "-10"},
+	}
+	p, err = ConvertToPointWithConfig(geojson, GeoConfig{Strict: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Longitude != 40 || p.Latitude != -10 {
+		t.Fatalf("got %+v", p)
+	}
+
+}
+
+func TestConvertToPointRejectsInvalidLatLon(t *testing.T) {
+
+	if _, err := ConvertToPoint([]interface{}{"200", "51.5"}); err == nil {
+		t.Fatal("expected an out-of-range longitude to be rejected")
+	}
+
+	if _, err := ConvertToPoint("not,a point"); err == nil {
+		t.Fatal("expected a malformed point to be rejected")
+	}
+
+}
+
+func TestConvertToWithPathPointStrict(t *testing.T) {
+
+	if _, err := ConvertTo("point<strict>", "", []interface{}{"40", "-10"}); err == nil {
+		t.Fatal("expected an ambiguous axis order to be rejected under TYPE point<strict>")
+	}
+
+	val, err := ConvertTo("geometry<strict>", "", map[string]interface{}{
+		"type":        "Point",
+		"coordinates": []interface{}{"40", "-10"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if val.(*sql.Point).Longitude != 40 {
+		t.Fatalf("got %+v", val)
+	}
+
+	if _, err := ConvertTo("point<loose>", "", []interface{}{"40", "-10"}); err == nil {
+		t.Fatal("expected an unsupported point modifier to be rejected")
+	}
+
+}
@@ -0,0 +1,100 @@
+// Copyright © 2016 Abcum Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conv
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+func ConvertToIP(obj interface{}) (val string, err error) {
+	return convertToIP(obj, "an IP address", 0)
+}
+
+func ConvertToIPv4(obj interface{}) (val string, err error) {
+	return convertToIP(obj, "an IPv4 address", 4)
+}
+
+func ConvertToIPv6(obj interface{}) (val string, err error) {
+	return convertToIP(obj, "an IPv6 address", 6)
+}
+
+func convertToIP(obj interface{}, expected string, version int) (val string, err error) {
+
+	str := fmt.Sprintf("%v", obj)
+
+	// net.ParseIP doesn't understand a `%zone` suffix, so split it off and
+	// reattach it after parsing/canonicalizing the address itself.
+	addr, zone := str, ""
+	if i := strings.IndexByte(str, '%'); i != -1 {
+		addr, zone = str[:i], str[i+1:]
+	}
+
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return "", newError(expected, obj)
+	}
+
+	if zone != "" && !strings.Contains(addr, ":") {
+		// a zone only makes sense on an IPv6 literal; To4() alone can't
+		// tell, since it's also non-nil for an IPv4-mapped IPv6 address
+		// such as "::ffff:192.0.2.1"
+		return "", newError(expected, obj)
+	}
+
+	if version == 4 && ip.To4() == nil {
+		return "", newError(expected, obj)
+	}
+
+	if version == 6 && ip.To4() != nil {
+		return "", newError(expected, obj)
+	}
+
+	if zone != "" {
+		return ip.String() + "%" + zone, nil
+	}
+
+	return ip.String(), nil
+
+}
+
+func ConvertToCIDR(obj interface{}) (val string, err error) {
+
+	str := fmt.Sprintf("%v", obj)
+
+	ip, ipnet, perr := net.ParseCIDR(str)
+	if perr != nil {
+		return "", newError("a CIDR range", obj)
+	}
+
+	ones, _ := ipnet.Mask.Size()
+
+	return fmt.Sprintf("%s/%d", ip.String(), ones), nil
+
+}
+
+func ConvertToMAC(obj interface{}) (val string, err error) {
+
+	str := fmt.Sprintf("%v", obj)
+
+	hw, perr := net.ParseMAC(str)
+	if perr != nil {
+		return "", newError("a MAC address", obj)
+	}
+
+	return hw.String(), nil
+
+}
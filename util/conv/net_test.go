@@ -0,0 +1,103 @@
+// Copyright © 2016 Abcum Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conv
+
+import "testing"
+
+func TestConvertToIP(t *testing.T) {
+
+	cases := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "192.0.2.1", want: "192.0.2.1"},
+		{in: "2001:DB8::1", want: "2001:db8::1"},
+		{in: "fe80::1%eth0", want: "fe80::1%eth0"},
+		{in: "::ffff:192.0.2.1%eth0", want: "192.0.2.1%eth0"},
+		{in: "not-an-ip", wantErr: true},
+		{in: "192.0.2.1%eth0", wantErr: true},
+	}
+
+	for _, c := range cases {
+		got, err := ConvertToIP(c.in)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("%q: expected error", c.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", c.in, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("%q: got %q, want %q", c.in, got, c.want)
+		}
+	}
+
+}
+
+func TestConvertToIPv4AndIPv6(t *testing.T) {
+
+	if _, err := ConvertToIPv4("2001:db8::1"); err == nil {
+		t.Error("expected ConvertToIPv4 to reject an IPv6 address")
+	}
+
+	if _, err := ConvertToIPv6("192.0.2.1"); err == nil {
+		t.Error("expected ConvertToIPv6 to reject an IPv4 address")
+	}
+
+	if _, err := ConvertToIPv4("192.0.2.1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if _, err := ConvertToIPv6("fe80::1%eth0"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+}
+
+func TestConvertToCIDR(t *testing.T) {
+
+	got, err := ConvertToCIDR("192.0.2.1/24")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "192.0.2.1/24" {
+		t.Fatalf("got %q", got)
+	}
+
+	if _, err := ConvertToCIDR("not a cidr"); err == nil {
+		t.Fatal("expected an error")
+	}
+
+}
+
+func TestConvertToMAC(t *testing.T) {
+
+	got, err := ConvertToMAC("01:23:45:67:89:AB")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "01:23:45:67:89:ab" {
+		t.Fatalf("got %q", got)
+	}
+
+	if _, err := ConvertToMAC("not a mac"); err == nil {
+		t.Fatal("expected an error")
+	}
+
+}
@@ -0,0 +1,63 @@
+// Copyright © 2016 Abcum Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conv
+
+import "sync"
+
+type ConverterFunc func(obj interface{}, k string) (interface{}, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]ConverterFunc)
+)
+
+// Register adds a custom named converter, usable as `TYPE <name>` in a
+// `DEFINE FIELD` statement. Registered names are checked before the
+// built-in set, so registering under a built-in name shadows it.
+func Register(name string, fn ConverterFunc) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = fn
+}
+
+func Unregister(name string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	delete(registry, name)
+}
+
+func Registered(name string) bool {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	_, ok := registry[name]
+	return ok
+}
+
+func Registry() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	return names
+}
+
+func lookup(name string) (fn ConverterFunc, ok bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	fn, ok = registry[name]
+	return
+}
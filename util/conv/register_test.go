@@ -0,0 +1,91 @@
+// Copyright © 2016 Abcum Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conv
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRegisterAndConvertTo(t *testing.T) {
+
+	Register("iban", func(obj interface{}, k string) (interface{}, error) {
+		return "IBAN:" + obj.(string), nil
+	})
+	defer Unregister("iban")
+
+	val, err := ConvertTo("iban", "", "GB29NWBK60161331926819")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if val != "IBAN:GB29NWBK60161331926819" {
+		t.Fatalf("got %v", val)
+	}
+
+}
+
+func TestRegisterShadowsBuiltin(t *testing.T) {
+
+	Register("url", func(obj interface{}, k string) (interface{}, error) {
+		return "custom", nil
+	})
+	defer Unregister("url")
+
+	val, err := ConvertTo("url", "", "not a url at all")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if val != "custom" {
+		t.Fatalf("expected registered converter to win, got %v", val)
+	}
+
+}
+
+func TestUnregister(t *testing.T) {
+
+	Register("iban", func(obj interface{}, k string) (interface{}, error) {
+		return nil, errors.New("boom")
+	})
+
+	Unregister("iban")
+
+	if Registered("iban") {
+		t.Fatal("expected iban to be unregistered")
+	}
+
+	for _, name := range Registry() {
+		if name == "iban" {
+			t.Fatal("expected iban to be absent from Registry()")
+		}
+	}
+
+}
+
+func TestRegistered(t *testing.T) {
+
+	if Registered("does-not-exist") {
+		t.Fatal("expected unregistered name to report false")
+	}
+
+	Register("isbn", func(obj interface{}, k string) (interface{}, error) {
+		return obj, nil
+	})
+	defer Unregister("isbn")
+
+	if !Registered("isbn") {
+		t.Fatal("expected registered name to report true")
+	}
+
+}
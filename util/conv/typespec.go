@@ -0,0 +1,90 @@
+// Copyright © 2016 Abcum Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conv
+
+import "strings"
+
+// TypeSpec describes a parameterized schema type such as `array<number>`,
+// `object<string,datetime>`, `point<strict>` or `datetime<layoutName>`, as
+// used by `DEFINE FIELD ... TYPE`. It lives in conv rather than sql, since
+// parsing a type descriptor is purely a conv concern and doesn't need
+// anything sql defines.
+type TypeSpec struct {
+	Base string
+	Key  string
+	Elem string
+}
+
+// ParseTypeSpec parses eg `array<number>`, `object<string,datetime>` or
+// `point<strict>`. ok is false when t isn't a parameterized type descriptor,
+// in which case callers should treat t as a plain type name instead.
+func ParseTypeSpec(t string) (spec *TypeSpec, ok bool) {
+
+	open := strings.IndexByte(t, '<')
+	if open == -1 || !strings.HasSuffix(t, ">") {
+		return nil, false
+	}
+
+	base := t[:open]
+	params := t[open+1 : len(t)-1]
+
+	switch base {
+	case "array", "point", "geometry", "datetime":
+		return &TypeSpec{Base: base, Elem: params}, true
+	case "object":
+		key, elem, ok := splitObjectParams(params)
+		if !ok {
+			return nil, false
+		}
+		return &TypeSpec{Base: base, Key: key, Elem: elem}, true
+	default:
+		return nil, false
+	}
+
+}
+
+// splitObjectParams splits `object<...>`'s params into its key and element
+// descriptors at the top-level comma, ie the one not nested inside a key
+// descriptor's own `<...>` (eg the key of `object<object<string,string>,
+// number>` is `object<string,string>`, not `object<string`). ok is false
+// when params has no top-level comma or its `<`/`>` are unbalanced.
+func splitObjectParams(params string) (key, elem string, ok bool) {
+
+	depth := 0
+	comma := -1
+
+	for i := 0; i < len(params); i++ {
+		switch params[i] {
+		case '<':
+			depth++
+		case '>':
+			depth--
+			if depth < 0 {
+				return "", "", false
+			}
+		case ',':
+			if depth == 0 && comma == -1 {
+				comma = i
+			}
+		}
+	}
+
+	if comma == -1 || depth != 0 {
+		return "", "", false
+	}
+
+	return params[:comma], params[comma+1:], true
+
+}